@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -29,16 +30,36 @@ type (
 		runnerPoolSize int
 		runners        []*Runner
 		mapResult      map[string]interface{}
+		events         *eventBus
+		logger         Logger
+		observer       Observer
 	}
 
 	// Runner hold the base context for asynctask runner
 	Runner struct {
-		b        *AsyncTask
-		id       string
-		multiple bool
-		f        func(param interface{}) (interface{}, error)
-		param    interface{}
-		timeout  time.Duration
+		b            *AsyncTask
+		id           string
+		multiple     bool
+		f            func(param interface{}) (interface{}, error)
+		fWithInputs  func(ctx context.Context, deps map[string]interface{}) (interface{}, error)
+		fWithContext func(tc TaskContext) (interface{}, error)
+		param        interface{}
+		timeout      time.Duration
+		dependsOn    []string
+		err          error
+		skipReason   string
+		dedupe       bool
+		poolWait     time.Duration
+		retryCount   int
+		timedOut     bool
+		goroutineID  uint64
+
+		onProgress func(ctx context.Context, progress float64, msg string)
+
+		retryAttempts int
+		retryBackoff  BackoffPolicy
+		retryIf       func(error) bool
+		onRetry       func(attempt int, err error)
 	}
 
 	safeResultChan struct {
@@ -64,17 +85,25 @@ func (src *safeResultChan) read() <-chan result {
 
 // write to safe write to a channel
 func (src *safeResultChan) write(data result) {
-	go func() {
-		src.mutex.Lock()
-		src.wg.Add(1)
+	src.mutex.Lock()
+	select {
+	case <-src.chClose:
+		// close already started: checking chClose and registering with wg must
+		// happen under the same lock close() uses, otherwise a write can commit
+		// to the send below after close()'s wg.Wait() already returned, and
+		// block forever with nobody left to receive
 		src.mutex.Unlock()
-		defer src.wg.Done()
+		return
+	default:
+	}
+	src.wg.Add(1)
+	src.mutex.Unlock()
 
+	go func() {
+		defer src.wg.Done()
 		select {
+		case src.chResult <- data:
 		case <-src.chClose:
-			return
-		default:
-			src.chResult <- data
 		}
 	}()
 }
@@ -83,12 +112,11 @@ func (src *safeResultChan) write(data result) {
 // every write will add 1 delta to waitgroup and when this func called, wait all the waitgroup
 // before closing the channel
 func (src *safeResultChan) close() {
-	close(src.chClose)
-
 	src.mutex.Lock()
-	src.wg.Wait()
+	close(src.chClose)
 	src.mutex.Unlock()
 
+	src.wg.Wait()
 	close(src.chResult)
 }
 
@@ -104,9 +132,24 @@ func NewAsyncTask(ctx context.Context) *AsyncTask {
 		runnerPoolSize: 0,
 		runners:        make([]*Runner, 0),
 		mapResult:      make(map[string]interface{}),
+		events:         newEventBus(),
+		logger:         defaultLogger,
+		observer:       noopObserver{},
 	}
 }
 
+// Results returns a channel of TaskEvent carrying each runner's lifecycle as it
+// happens, instead of requiring callers to wait for StartAndWait to return.
+// StartAndWait closes the channel once every runner has finished.
+//
+// The channel is buffered; if the caller falls more than eventBusBuffer events
+// behind, further events are dropped rather than blocking the runner that
+// emitted them. A consumer is free to stop reading from Results early (e.g. on
+// its own early return) without risking a deadlock in StartAndWait.
+func (b *AsyncTask) Results() <-chan TaskEvent {
+	return b.events.results()
+}
+
 func (b *AsyncTask) cancelContext() {
 	if b.cancelOnError {
 		b.cancel()
@@ -127,10 +170,30 @@ func (b *AsyncTask) CancelOnError(flag bool) *AsyncTask {
 }
 
 // StartAndWait start the asynctask and wait for all task finish
+// if any runner declares a dependency via Runner.After, the runners are scheduled
+// in topological order instead of all at once, see startAndWaitDAG
+//
+// When the context is canceled (directly, or via CancelOnError) while a runner is
+// still in flight, that runner still runs to completion (including its panic
+// recovery, Logger and Observer calls) before StartAndWait returns; only a
+// runner's own SetTimeout bounds how long a context-ignoring function can run
 func (b *AsyncTask) StartAndWait() error {
+	defer b.events.close()
+
+	if b.hasDependencies() {
+		return b.startAndWaitDAG()
+	}
+
 	sem := make(chan int, b.runnerPoolSize)
 	mapID := make(map[string]bool)
+	dedupedIDs := b.dedupedIDs()
 	for _, runner := range b.runners {
+		// dedupe follower: a previous runner already registered under this ID will
+		// produce the shared result, so this one does not run at all
+		if mapID[runner.id] && dedupedIDs[runner.id] && !runner.multiple {
+			continue
+		}
+
 		// check runner ID, if runner multiple != true and the ID is exist before,
 		// return error and cancel context
 		if mapID[runner.id] && !runner.multiple {
@@ -142,6 +205,7 @@ func (b *AsyncTask) StartAndWait() error {
 
 		if b.runnerPoolSize > 0 {
 			cont := true
+			waitStart := time.Now()
 			select {
 			case <-b.ctx.Done():
 				cont = false
@@ -151,6 +215,7 @@ func (b *AsyncTask) StartAndWait() error {
 			if !cont {
 				break
 			}
+			runner.poolWait = time.Since(waitStart)
 		}
 
 		b.wg.Add(1)
@@ -171,6 +236,43 @@ func (b *AsyncTask) GetResult(id string) interface{} {
 	return b.mapResult[id]
 }
 
+// ResultOrErr is like GetResult, but distinguishes a missing/not-yet-finished result
+// from one that is legitimately nil, and reports a skipped runner (see Runner.After)
+// as a *TaskError with CodeCanceled instead of silently returning nil
+func (b *AsyncTask) ResultOrErr(id string) (interface{}, error) {
+	if reason, skipped := b.SkipReason(id); skipped {
+		return nil, newTaskError(CodeCanceled, reason, nil)
+	}
+
+	if v, ok := b.mapResult[id]; ok {
+		return v, nil
+	}
+
+	return nil, newTaskError(CodeNotReady, fmt.Sprintf("result for ID %s is not ready", id), nil)
+}
+
+// SkipReason returns why a runner was skipped instead of executed, which happens
+// when one of its dependencies (see Runner.After) failed and CancelOnError(false)
+// is set. The second return value is false if the runner was not skipped.
+func (b *AsyncTask) SkipReason(id string) (string, bool) {
+	for _, r := range b.runners {
+		if r.id == id && r.skipReason != "" {
+			return r.skipReason, true
+		}
+	}
+	return "", false
+}
+
+// hasDependencies returns true if any registered runner declared a dependency via After
+func (b *AsyncTask) hasDependencies() bool {
+	for _, r := range b.runners {
+		if len(r.dependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // NewRunner create new asynctask runner
 func (b *AsyncTask) NewRunner() *Runner {
 	return &Runner{
@@ -178,13 +280,40 @@ func (b *AsyncTask) NewRunner() *Runner {
 	}
 }
 
-func (r *Runner) recovery() {
-	rc := recover()
-	if rc != nil {
-		r.b.mutex.Lock()
-		r.b.err = fmt.Errorf("panic recovered. message: %v. stacktrace: %s", rc, string(debug.Stack()))
-		r.b.mutex.Unlock()
-		r.b.cancelContext()
+// recoverPanic records an already-recovered panic value as this runner's error
+// and returns it so the caller can report it like any other failure instead of
+// leaving the runner with no result at all.
+//
+// recover() must be called directly inside a deferred function to have any
+// effect; wrapping it in a helper that calls recover() itself does not work
+// once that helper is invoked from inside the deferred closure rather than
+// being the deferred call itself. So callers recover the panic themselves and
+// hand the value here for the bookkeeping.
+func (r *Runner) recoverPanic(rc interface{}) error {
+	stack := debug.Stack()
+	taskErr := newTaskError(CodePanicked, fmt.Sprintf("panic recovered. message: %v", rc), nil)
+	taskErr.stack = stack
+
+	r.b.mutex.Lock()
+	r.err = taskErr
+	r.b.err = taskErr
+	r.b.mutex.Unlock()
+	r.b.cancelContext()
+
+	r.b.logger.Error(fmt.Sprintf("%s. stacktrace: %s", taskErr.Error(), stack))
+	r.b.observer.OnPanic(r.id, rc, stack)
+
+	return taskErr
+}
+
+// deliverOnce writes res to ch unless do()/attempt()'s select has already moved
+// on without it (e.g. the shared context was canceled first), in which case the
+// write would otherwise block forever with nobody left to receive it
+func (r *Runner) deliverOnce(ch *safeResultChan, res result) {
+	select {
+	case <-ch.read():
+	default:
+		ch.write(res)
 	}
 }
 
@@ -192,6 +321,7 @@ func (r *Runner) processErr(err error) {
 	r.b.cancelContext()
 	r.b.mutex.Lock()
 	defer r.b.mutex.Unlock()
+	r.err = err
 	r.b.err = err
 	return
 }
@@ -221,7 +351,90 @@ func (r *Runner) processResp(id string, resp interface{}) {
 	r.b.mapResult[id] = resp
 }
 
+// invoke calls the user provided function, resolving dependency results into a
+// map when the runner was set up with SetFuncWithInputs instead of SetFunc
+func (r *Runner) invoke(ctx context.Context) (interface{}, error) {
+	if r.fWithContext != nil {
+		return r.fWithContext(TaskContext{
+			Ctx: ctx,
+			Progress: func(progress float64, msg string) {
+				if r.onProgress != nil {
+					r.onProgress(ctx, progress, msg)
+				}
+			},
+		})
+	}
+
+	if r.fWithInputs != nil {
+		deps := make(map[string]interface{}, len(r.dependsOn))
+		for _, id := range r.dependsOn {
+			deps[id] = r.b.mapResult[id]
+		}
+		return r.fWithInputs(ctx, deps)
+	}
+
+	return r.f(r.param)
+}
+
 func (r *Runner) do() {
+	r.b.events.emit(TaskEvent{ID: r.id, Kind: EventStarted})
+	r.b.observer.OnStart(r.id)
+	start := time.Now()
+
+	ch := newSafeResultChan()
+	defer ch.close()
+
+	// finished is closed once the goroutine below (including its deferred
+	// recovery) has fully run, so the ctx.Done() case can wait for it: without
+	// that, a canceled runner would return here while its recovery() goroutine
+	// is still calling the Logger/Observer concurrently with whatever the
+	// caller of StartAndWait does next
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+
+		var resp interface{}
+		var err error
+		// deferred so a panic still reaches ch: recover() (which must be called
+		// directly here, not inside a helper) turns it into err via recoverPanic,
+		// and this still runs the write that lets do()'s select resolve via
+		// ch.read() instead of relying on cancelContext() (a no-op when
+		// CancelOnError(false)) to ever unblock it
+		defer func() {
+			if rc := recover(); rc != nil {
+				err = r.recoverPanic(rc)
+			}
+			r.deliverOnce(ch, result{resp: resp, err: err})
+		}()
+
+		resp, err = r.invokeWithRetry()
+	}()
+
+	select {
+	case res := <-ch.read():
+		r.b.observer.OnFinish(r.id, RunnerStats{
+			Duration:    time.Since(start),
+			RetryCount:  r.retryCount,
+			TimedOut:    r.timedOut,
+			GoroutineID: atomic.LoadUint64(&r.goroutineID),
+			PoolWait:    r.poolWait,
+		})
+
+		if res.err != nil {
+			r.processErr(res.err)
+			r.b.events.emit(TaskEvent{ID: r.id, Err: res.err, Kind: EventFailed})
+			return
+		}
+		r.processResp(r.id, res.resp)
+		r.b.events.emit(TaskEvent{ID: r.id, Resp: res.resp, Kind: EventCompleted})
+	case <-r.b.ctx.Done():
+		<-finished
+	}
+}
+
+// attempt runs a single invocation of the runner's function, honoring the
+// per-attempt timeout set via SetTimeout and the parent AsyncTask's overall deadline
+func (r *Runner) attempt() (interface{}, error) {
 	ch := newSafeResultChan()
 	defer ch.close()
 
@@ -232,30 +445,38 @@ func (r *Runner) do() {
 		defer cancel()
 	}
 
+	// finished is closed once the goroutine below (including its deferred
+	// recovery) has fully run, see the matching comment in do()
+	finished := make(chan struct{})
 	go func() {
-		defer r.recovery()
-		resp, err := r.f(r.param)
+		defer close(finished)
 
-		select {
-		case <-ch.read():
-		default:
-			ch.write(result{
-				resp: resp,
-				err:  err,
-			})
-		}
+		var resp interface{}
+		var err error
+		defer func() {
+			if rc := recover(); rc != nil {
+				err = r.recoverPanic(rc)
+			}
+			r.deliverOnce(ch, result{resp: resp, err: err})
+		}()
+
+		atomic.StoreUint64(&r.goroutineID, currentGoroutineID())
+		resp, err = r.invoke(runnerCtx)
 	}()
 
 	select {
 	case res := <-ch.read():
-		if res.err != nil {
-			r.processErr(res.err)
-			return
-		}
-		r.processResp(r.id, res.resp)
+		return res.resp, res.err
 	case <-r.b.ctx.Done():
+		<-finished
+		return nil, newTaskError(CodeCanceled, fmt.Sprintf("runner with ID %s was canceled", r.id), r.b.ctx.Err())
 	case <-runnerCtx.Done():
-		r.processErr(fmt.Errorf("runner with ID %s reached its time limit", r.id))
+		// unlike the b.ctx.Done() case above, do not wait on finished here: a
+		// per-attempt timeout exists specifically to bound how long a function
+		// that ignores its context can hold up the caller, so it must return
+		// immediately and let the goroutine's eventual recovery() run orphaned
+		r.timedOut = true
+		return nil, newTaskError(CodeTimeout, fmt.Sprintf("runner with ID %s reached its time limit", r.id), nil)
 	}
 }
 
@@ -265,6 +486,22 @@ func (r *Runner) SetFunc(f func(param interface{}) (interface{}, error)) *Runner
 	return r
 }
 
+// SetFuncWithInputs is like SetFunc, but the function receives the results of the
+// runners declared with After as a map keyed by their ID, once they all complete
+// successfully. Use this instead of SetFunc when the runner depends on other runners.
+func (r *Runner) SetFuncWithInputs(f func(ctx context.Context, deps map[string]interface{}) (interface{}, error)) *Runner {
+	r.fWithInputs = f
+	return r
+}
+
+// After declares that this runner must only start once every runner registered
+// with the given IDs has finished successfully. StartAndWait schedules runners
+// with dependencies in topological order instead of firing them all at once.
+func (r *Runner) After(ids ...string) *Runner {
+	r.dependsOn = append(r.dependsOn, ids...)
+	return r
+}
+
 // SetParam is to set param that will be thrown to executed function
 func (r *Runner) SetParam(param interface{}) *Runner {
 	r.param = param
@@ -0,0 +1,179 @@
+package asynctask
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// buildDependencyGraph resolves the DAG described by each runner's After(...) ids.
+// It returns, for every runner ID, the list of runners that depend on it, so that
+// startAndWaitDAG can release them once their dependencies finish.
+func (b *AsyncTask) buildDependencyGraph() (map[string][]*Runner, error) {
+	byID := make(map[string]*Runner, len(b.runners))
+	for _, r := range b.runners {
+		byID[r.id] = r
+	}
+
+	dependents := make(map[string][]*Runner)
+	for _, r := range b.runners {
+		for _, dep := range r.dependsOn {
+			if _, ok := byID[dep]; !ok {
+				return nil, fmt.Errorf("runner %s depends on unknown ID %s", r.id, dep)
+			}
+			dependents[dep] = append(dependents[dep], r)
+		}
+	}
+
+	if cycle := detectCycle(b.runners, byID); cycle != "" {
+		return nil, fmt.Errorf("dependency cycle detected: %s", cycle)
+	}
+
+	return dependents, nil
+}
+
+// detectCycle walks the dependency graph with a DFS and returns a human readable
+// path describing the cycle (e.g. "A -> B -> A"), or an empty string if none is found
+func detectCycle(runners []*Runner, byID map[string]*Runner) string {
+	const (
+		white = iota
+		gray
+		black
+	)
+
+	state := make(map[string]int, len(runners))
+	var path []string
+
+	var visit func(id string) string
+	visit = func(id string) string {
+		state[id] = gray
+		path = append(path, id)
+
+		for _, dep := range byID[id].dependsOn {
+			switch state[dep] {
+			case gray:
+				return strings.Join(append(path, dep), " -> ")
+			case white:
+				if cycle := visit(dep); cycle != "" {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[id] = black
+		return ""
+	}
+
+	for _, r := range runners {
+		if state[r.id] == white {
+			if cycle := visit(r.id); cycle != "" {
+				return cycle
+			}
+		}
+	}
+
+	return ""
+}
+
+// startAndWaitDAG schedules runners in topological order, releasing a runner into
+// the worker pool as soon as every runner it depends on (see Runner.After) has
+// finished. It honors runnerPoolSize the same way StartAndWait does for independent
+// runners. When an upstream runner fails and CancelOnError(false) is set, every
+// runner downstream of it is marked skipped instead of being executed or cancelled.
+func (b *AsyncTask) startAndWaitDAG() error {
+	dependents, err := b.buildDependencyGraph()
+	if err != nil {
+		b.cancelContext()
+		return err
+	}
+
+	mapID := make(map[string]bool, len(b.runners))
+	pending := make(map[string]int, len(b.runners))
+	dedupedIDs := b.dedupedIDs()
+	dedupeFollower := make(map[*Runner]bool, len(b.runners))
+	for _, r := range b.runners {
+		// dedupe follower: a previous runner already registered under this ID will
+		// produce the shared result, so this one does not run at all
+		if mapID[r.id] && dedupedIDs[r.id] && !r.multiple {
+			dedupeFollower[r] = true
+			continue
+		}
+
+		if mapID[r.id] && !r.multiple {
+			b.cancelContext()
+			return fmt.Errorf("ID %s have been used before without `SetMultiple()`", r.id)
+		}
+		mapID[r.id] = true
+		pending[r.id] = len(r.dependsOn)
+	}
+
+	sem := make(chan int, b.runnerPoolSize)
+	var graphMutex sync.Mutex
+
+	var run func(r *Runner)
+	var release func(r *Runner)
+
+	run = func(r *Runner) {
+		defer b.wg.Done()
+
+		if r.skipReason != "" {
+			b.events.emit(TaskEvent{ID: r.id, Err: errors.New(r.skipReason), Kind: EventSkipped})
+		} else {
+			select {
+			case <-b.ctx.Done():
+			default:
+				if b.runnerPoolSize > 0 {
+					waitStart := time.Now()
+					select {
+					case sem <- 1:
+						r.poolWait = time.Since(waitStart)
+						r.do()
+						<-sem
+					case <-b.ctx.Done():
+					}
+				} else {
+					r.do()
+				}
+			}
+		}
+
+		release(r)
+	}
+
+	release = func(r *Runner) {
+		graphMutex.Lock()
+		defer graphMutex.Unlock()
+
+		failed := r.err != nil || r.skipReason != ""
+
+		for _, dep := range dependents[r.id] {
+			if failed && !b.cancelOnError && dep.skipReason == "" {
+				dep.skipReason = fmt.Sprintf("skipped due to upstream %s", r.id)
+			}
+
+			pending[dep.id]--
+			if pending[dep.id] == 0 {
+				b.wg.Add(1)
+				go run(dep)
+			}
+		}
+	}
+
+	graphMutex.Lock()
+	for _, r := range b.runners {
+		if dedupeFollower[r] {
+			continue
+		}
+		if pending[r.id] == 0 {
+			b.wg.Add(1)
+			go run(r)
+		}
+	}
+	graphMutex.Unlock()
+
+	b.wg.Wait()
+	return b.err
+}
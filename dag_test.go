@@ -0,0 +1,108 @@
+package asynctask
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncTaskDAGSucceed(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return 1, nil
+	}).Register("a")
+
+	asyncTask.NewRunner().SetFuncWithInputs(func(ctx context.Context, deps map[string]interface{}) (interface{}, error) {
+		return deps["a"].(int) + 1, nil
+	}).After("a").Register("b")
+
+	asyncTask.NewRunner().SetFuncWithInputs(func(ctx context.Context, deps map[string]interface{}) (interface{}, error) {
+		return deps["b"].(int) + 1, nil
+	}).After("b").Register("c")
+
+	err := asyncTask.StartAndWait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, asyncTask.GetResult("a"))
+	assert.Equal(t, 2, asyncTask.GetResult("b"))
+	assert.Equal(t, 3, asyncTask.GetResult("c"))
+}
+
+func TestAsyncTaskDAGCycleError(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return nil, nil
+	}).After("b").Register("a")
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return nil, nil
+	}).After("a").Register("b")
+
+	err := asyncTask.StartAndWait()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "dependency cycle detected")
+}
+
+func TestAsyncTaskDAGSkipDownstreamOnError(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	asyncTask.CancelOnError(false)
+
+	testErr := fmt.Errorf("test error")
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return nil, testErr
+	}).Register("a")
+
+	asyncTask.NewRunner().SetFuncWithInputs(func(ctx context.Context, deps map[string]interface{}) (interface{}, error) {
+		return "should not run", nil
+	}).After("a").Register("b")
+
+	err := asyncTask.StartAndWait()
+
+	assert.Error(t, err)
+	assert.Nil(t, asyncTask.GetResult("b"))
+
+	reason, skipped := asyncTask.SkipReason("b")
+	assert.True(t, skipped)
+	assert.Contains(t, reason, "upstream a")
+}
+
+func TestAsyncTaskDAGSkipDownstreamOnPanic(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	asyncTask.CancelOnError(false)
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		panic("boom")
+	}).Register("a")
+
+	asyncTask.NewRunner().SetFuncWithInputs(func(ctx context.Context, deps map[string]interface{}) (interface{}, error) {
+		return "should not run", nil
+	}).After("a").Register("b")
+
+	err := asyncTask.StartAndWait()
+
+	assert.Error(t, err)
+	assert.Nil(t, asyncTask.GetResult("b"))
+
+	reason, skipped := asyncTask.SkipReason("b")
+	assert.True(t, skipped)
+	assert.Contains(t, reason, "upstream a")
+}
+
+func TestAsyncTaskDAGUnknownDependencyError(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return nil, nil
+	}).After("missing").Register("a")
+
+	err := asyncTask.StartAndWait()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown ID missing")
+}
@@ -0,0 +1,23 @@
+package asynctask
+
+// SetDedupe marks the runner as part of a singleflight-style dedupe group: when
+// multiple runners share the same ID and at least one of them calls SetDedupe,
+// only the first one registered actually runs its function. The others neither
+// run nor error with the usual "ID have been used before" error, and GetResult(id)
+// returns the leader's shared result (and error) to every caller
+func (r *Runner) SetDedupe() *Runner {
+	r.dedupe = true
+	return r
+}
+
+// dedupedIDs returns the set of runner IDs that have at least one runner
+// registered with SetDedupe, and therefore should be deduplicated at scheduling time
+func (b *AsyncTask) dedupedIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for _, r := range b.runners {
+		if r.dedupe {
+			ids[r.id] = true
+		}
+	}
+	return ids
+}
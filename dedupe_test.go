@@ -0,0 +1,50 @@
+package asynctask
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncTaskDedupeSharesResult(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+
+	var calls int32
+	f := func(param interface{}) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return "shared", nil
+	}
+
+	asyncTask.NewRunner().SetFunc(f).SetDedupe().Register("key")
+	asyncTask.NewRunner().SetFunc(f).SetDedupe().Register("key")
+	asyncTask.NewRunner().SetFunc(f).SetDedupe().Register("key")
+
+	err := asyncTask.StartAndWait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, "shared", asyncTask.GetResult("key"))
+}
+
+func TestAsyncTaskDedupeSharesError(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	asyncTask.CancelOnError(false)
+
+	testErr := errors.New("shared failure")
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return nil, testErr
+	}).SetDedupe().Register("key")
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		t.Fatal("follower function must not run")
+		return nil, nil
+	}).SetDedupe().Register("key")
+
+	err := asyncTask.StartAndWait()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), testErr.Error())
+}
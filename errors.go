@@ -0,0 +1,112 @@
+package asynctask
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// TaskErrorCode classifies why a TaskError was returned, similar to how gRPC/Kratos
+// error packages expose a machine-readable reason alongside the human message
+type TaskErrorCode string
+
+const (
+	// CodeNotReady means the result for an ID has not been produced yet
+	CodeNotReady TaskErrorCode = "NOT_READY"
+	// CodePanicked means the runner's function panicked
+	CodePanicked TaskErrorCode = "PANICKED"
+	// CodeHandlerFailed means the runner's function returned an error
+	CodeHandlerFailed TaskErrorCode = "HANDLER_FAILED"
+	// CodeTypeMismatch means a result could not be converted to the requested type
+	CodeTypeMismatch TaskErrorCode = "TYPE_MISMATCH"
+	// CodeNilResult means a nil result was passed to a Result* helper
+	CodeNilResult TaskErrorCode = "NIL_RESULT"
+	// CodeCanceled means the runner was canceled, either because the AsyncTask's
+	// context was canceled or because an upstream dependency (see Runner.After) failed
+	CodeCanceled TaskErrorCode = "CANCELED"
+	// CodeTimeout means the runner did not finish within its SetTimeout limit
+	CodeTimeout TaskErrorCode = "TIMEOUT"
+)
+
+// TaskError is the error type returned by asynctask when an error can be
+// programmatically classified, carrying a TaskErrorCode, a human message, an
+// optional wrapped cause (use errors.Is/errors.As to inspect it), and a stack
+// trace captured when the TaskError was created
+type TaskError struct {
+	code    TaskErrorCode
+	message string
+	cause   error
+	stack   []byte
+}
+
+func newTaskError(code TaskErrorCode, message string, cause error) *TaskError {
+	return &TaskError{
+		code:    code,
+		message: message,
+		cause:   cause,
+		stack:   debug.Stack(),
+	}
+}
+
+// Error implements the error interface
+func (e *TaskError) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %s: %s", e.code, e.message, e.cause)
+	}
+	return fmt.Sprintf("%s: %s", e.code, e.message)
+}
+
+// Unwrap returns the wrapped cause, if any, so errors.Is/errors.As can see through it
+func (e *TaskError) Unwrap() error {
+	return e.cause
+}
+
+// Stack returns the stack trace captured when the TaskError was created
+func (e *TaskError) Stack() []byte {
+	return e.stack
+}
+
+// MarshalJSON implements json.Marshaler, producing a stable payload for tasks whose
+// results are shipped over the wire
+func (e *TaskError) MarshalJSON() ([]byte, error) {
+	payload := struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Cause   string `json:"cause,omitempty"`
+	}{
+		Code:    string(e.code),
+		Message: e.message,
+	}
+
+	if e.cause != nil {
+		payload.Cause = e.cause.Error()
+	}
+
+	return json.Marshal(payload)
+}
+
+// Code returns the TaskErrorCode carried by err, or an empty TaskErrorCode if err
+// is not a *TaskError (or does not wrap one)
+func Code(err error) TaskErrorCode {
+	var taskErr *TaskError
+	if errors.As(err, &taskErr) {
+		return taskErr.code
+	}
+	return ""
+}
+
+// Reason returns the human readable message carried by err, or an empty string if
+// err is not a *TaskError (or does not wrap one)
+func Reason(err error) string {
+	var taskErr *TaskError
+	if errors.As(err, &taskErr) {
+		return taskErr.message
+	}
+	return ""
+}
+
+// IsNotReady reports whether err is a *TaskError with CodeNotReady
+func IsNotReady(err error) bool {
+	return Code(err) == CodeNotReady
+}
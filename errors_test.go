@@ -0,0 +1,77 @@
+package asynctask
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskErrorCodeAndReason(t *testing.T) {
+	_, err := Result[string](nil)
+
+	assert.Equal(t, CodeNilResult, Code(err))
+	assert.Equal(t, "result is nil", Reason(err))
+	assert.True(t, IsNotReady(errorWithCode(CodeNotReady)))
+	assert.False(t, IsNotReady(err))
+}
+
+func errorWithCode(code TaskErrorCode) error {
+	return newTaskError(code, "test", nil)
+}
+
+func TestTaskErrorMarshalJSON(t *testing.T) {
+	err := newTaskError(CodeTypeMismatch, "cannot convert", errors.New("underlying"))
+
+	b, marshalErr := json.Marshal(err)
+	assert.NoError(t, marshalErr)
+	assert.JSONEq(t, `{"code":"TYPE_MISMATCH","message":"cannot convert","cause":"underlying"}`, string(b))
+}
+
+func TestTaskErrorCodeOfPlainError(t *testing.T) {
+	assert.Equal(t, TaskErrorCode(""), Code(errors.New("plain")))
+}
+
+func TestAsyncTaskResultOrErrNotReady(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return nil, nil
+	}).Register("id1")
+
+	_, err := asyncTask.ResultOrErr("unknown")
+
+	assert.True(t, IsNotReady(err))
+}
+
+func TestAsyncTaskResultOrErrSkipped(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	asyncTask.CancelOnError(false)
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return nil, errors.New("boom")
+	}).Register("a")
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return "unused", nil
+	}).After("a").Register("b")
+
+	_ = asyncTask.StartAndWait()
+
+	_, err := asyncTask.ResultOrErr("b")
+	assert.Equal(t, CodeCanceled, Code(err))
+}
+
+func TestAsyncTaskTimeoutProducesTaskError(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		time.Sleep(50 * time.Millisecond)
+		return "late", nil
+	}).SetTimeout(5 * time.Millisecond).Register("id1")
+
+	err := asyncTask.StartAndWait()
+
+	assert.Equal(t, CodeTimeout, Code(err))
+}
@@ -0,0 +1,116 @@
+package asynctask
+
+import (
+	"context"
+	"sync"
+)
+
+// EventKind describes what stage of its lifecycle a runner was in when a TaskEvent was emitted
+type EventKind int
+
+const (
+	// EventStarted is emitted right before a runner's function is invoked
+	EventStarted EventKind = iota
+	// EventCompleted is emitted when a runner's function returns successfully
+	EventCompleted
+	// EventFailed is emitted when a runner's function returns an error that isn't retried further
+	EventFailed
+	// EventRetrying is emitted right before a failed runner's function is retried, see Runner.SetRetry
+	EventRetrying
+	// EventSkipped is emitted when a runner is skipped because a dependency failed, see Runner.After
+	EventSkipped
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventStarted:
+		return "started"
+	case EventCompleted:
+		return "completed"
+	case EventFailed:
+		return "failed"
+	case EventRetrying:
+		return "retrying"
+	case EventSkipped:
+		return "skipped"
+	default:
+		return "unknown"
+	}
+}
+
+// TaskEvent carries a single lifecycle update for a runner, delivered through AsyncTask.Results
+type TaskEvent struct {
+	ID   string
+	Resp interface{}
+	Err  error
+	Kind EventKind
+}
+
+// TaskContext is passed to a runner's function when it is set with SetFuncWithContext,
+// giving it access to the runner's context and a way to report progress
+type TaskContext struct {
+	Ctx context.Context
+	// Progress reports how far along the function is (e.g. 0 to 1) with a human readable
+	// message. It is a no-op unless the runner was configured with Runner.SetProgress
+	Progress func(progress float64, msg string)
+}
+
+// SetFuncWithContext is like SetFunc, but the function receives a TaskContext instead of
+// a plain param, giving it access to the runner's context and progress reporting
+func (r *Runner) SetFuncWithContext(f func(tc TaskContext) (interface{}, error)) *Runner {
+	r.fWithContext = f
+	return r
+}
+
+// SetProgress sets the callback invoked when the runner's function calls TaskContext.Progress,
+// which only happens if the runner was set up with SetFuncWithContext
+func (r *Runner) SetProgress(f func(ctx context.Context, progress float64, msg string)) *Runner {
+	r.onProgress = f
+	return r
+}
+
+// eventBusBuffer is how many TaskEvent a consumer can fall behind by before
+// emit starts dropping events on its behalf, see eventBus.emit
+const eventBusBuffer = 64
+
+// eventBus fans TaskEvent out to the channel returned by AsyncTask.Results. emit sends
+// on a buffered channel and drops the event instead of blocking if the buffer is full,
+// so a consumer that stops draining Results early can never deadlock StartAndWait
+type eventBus struct {
+	ch      chan TaskEvent
+	mutex   sync.Mutex
+	started bool
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{ch: make(chan TaskEvent, eventBusBuffer)}
+}
+
+func (e *eventBus) results() <-chan TaskEvent {
+	e.mutex.Lock()
+	e.started = true
+	e.mutex.Unlock()
+	return e.ch
+}
+
+// emit is a no-op until Results has been called, so AsyncTask does not pay for an
+// event bus nobody is listening to. If the consumer has fallen behind by more than
+// eventBusBuffer events, the event is dropped rather than blocking the runner that
+// emitted it
+func (e *eventBus) emit(evt TaskEvent) {
+	e.mutex.Lock()
+	if !e.started {
+		e.mutex.Unlock()
+		return
+	}
+	e.mutex.Unlock()
+
+	select {
+	case e.ch <- evt:
+	default:
+	}
+}
+
+func (e *eventBus) close() {
+	close(e.ch)
+}
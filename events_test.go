@@ -0,0 +1,95 @@
+package asynctask
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncTaskResultsStream(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	events := asyncTask.Results()
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return "ok", nil
+	}).Register("id1")
+
+	var kinds []EventKind
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for evt := range events {
+			kinds = append(kinds, evt.Kind)
+		}
+	}()
+
+	err := asyncTask.StartAndWait()
+	assert.NoError(t, err)
+
+	<-done
+	assert.Equal(t, []EventKind{EventStarted, EventCompleted}, kinds)
+}
+
+func TestAsyncTaskResultsStreamFailed(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	events := asyncTask.Results()
+
+	testErr := errors.New("boom")
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return nil, testErr
+	}).Register("id1")
+
+	var kinds []EventKind
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for evt := range events {
+			kinds = append(kinds, evt.Kind)
+		}
+	}()
+
+	_ = asyncTask.StartAndWait()
+
+	<-done
+	assert.Equal(t, []EventKind{EventStarted, EventFailed}, kinds)
+}
+
+func TestAsyncTaskResultsStreamEarlyExitDoesNotDeadlock(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	events := asyncTask.Results()
+
+	for i := 0; i < eventBusBuffer*2; i++ {
+		asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+			return "ok", nil
+		}).SetMultiple().Register("id1")
+	}
+
+	// a consumer that stops draining Results well before StartAndWait is done must
+	// not block it: emit drops events past eventBusBuffer instead of blocking
+	go func() {
+		<-events
+	}()
+
+	err := asyncTask.StartAndWait()
+	assert.NoError(t, err)
+}
+
+func TestAsyncTaskProgressCallback(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+
+	var messages []string
+	asyncTask.NewRunner().SetFuncWithContext(func(tc TaskContext) (interface{}, error) {
+		tc.Progress(0.5, "halfway")
+		return "done", nil
+	}).SetProgress(func(ctx context.Context, progress float64, msg string) {
+		messages = append(messages, msg)
+	}).Register("id1")
+
+	err := asyncTask.StartAndWait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"halfway"}, messages)
+	assert.Equal(t, "done", asyncTask.GetResult("id1"))
+}
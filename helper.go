@@ -1,55 +1,106 @@
 package asynctask
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"reflect"
 
 	jsoniter "github.com/json-iterator/go"
 )
 
+// ErrNilResult is the cause wrapped by the *TaskError returned by Result and the
+// Result* helpers when the asynctask runner result passed in is nil
+var ErrNilResult = errors.New("asynctask: result is nil")
+
+// ErrTypeMismatch is the cause wrapped by the *TaskError returned when a result
+// cannot be turned into the requested type, reporting both the type that was
+// expected and the actual type of the source value
+type ErrTypeMismatch struct {
+	Expected reflect.Type
+	Actual   reflect.Type
+}
+
+func (e *ErrTypeMismatch) Error() string {
+	return fmt.Sprintf("asynctask: cannot convert %s to %s", e.Actual, e.Expected)
+}
+
+// Result parses an asynctask runner result into T. If v already holds a T, it is
+// returned directly via a type assertion. Otherwise, v is treated as a JSON-ish
+// source (map[string]interface{}, []interface{}, []byte, json.RawMessage, ...) and
+// decoded into T honoring json tags, which lets a single helper replace a dedicated
+// function per return type (e.g. Result[MyStruct](...) or Result[[]int](...)).
+// Errors are returned as *TaskError: CodeNilResult for a nil v, CodeTypeMismatch
+// when v cannot be converted to T
+func Result[T any](v interface{}) (T, error) {
+	var zero T
+
+	if v == nil {
+		return zero, newTaskError(CodeNilResult, "result is nil", ErrNilResult)
+	}
+
+	if t, ok := v.(T); ok {
+		return t, nil
+	}
+
+	if err := decodeInto(v, &zero); err != nil {
+		mismatch := &ErrTypeMismatch{Expected: reflect.TypeOf(zero), Actual: reflect.TypeOf(v)}
+		return zero, newTaskError(CodeTypeMismatch, mismatch.Error(), mismatch)
+	}
+
+	return zero, nil
+}
+
+// decodeInto marshals v to JSON and unmarshals it into out, failing fast for source
+// kinds that cannot be meaningfully marshaled instead of letting json.Marshal try
+func decodeInto(v interface{}, out interface{}) error {
+	switch b := v.(type) {
+	case []byte:
+		return jsoniter.Unmarshal(b, out)
+	case json.RawMessage:
+		return jsoniter.Unmarshal(b, out)
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return fmt.Errorf("asynctask: cannot decode value of kind %s", reflect.ValueOf(v).Kind())
+	}
+
+	b, err := jsoniter.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return jsoniter.Unmarshal(b, out)
+}
+
 // ResultString parse asynctask runner result to string
 // return error if actual result is not string
 func ResultString(i interface{}) (string, error) {
-	x, ok := i.(string)
-	if !ok {
-		return "", errors.New("interface is not string")
-	}
-
-	return x, nil
+	return Result[string](i)
 }
 
 // ResultInt parse asynctask runner result to int
 // return error if actual result is not int
 func ResultInt(i interface{}) (int, error) {
-	x, ok := i.(int)
-	if !ok {
-		return 0, errors.New("interface is not int")
-	}
-
-	return x, nil
+	return Result[int](i)
 }
 
 // ResultBool parse asynctask runner result to bool
 // return error if actual result is not bool
 func ResultBool(i interface{}) (bool, error) {
-	x, ok := i.(bool)
-	if !ok {
-		return false, errors.New("interface is not bool")
-	}
-
-	return x, nil
+	return Result[bool](i)
 }
 
 // ResultObj parse asynctask runner result to destination interface
 // return error if actual result schema and destination schema is not match
 func ResultObj(i interface{}, o interface{}) error {
-	b, err := jsoniter.Marshal(i)
-	if err != nil {
-		return err
+	if i == nil {
+		return newTaskError(CodeNilResult, "result is nil", ErrNilResult)
 	}
 
-	err = jsoniter.Unmarshal(b, o)
-	if err != nil {
-		return err
+	if err := decodeInto(i, o); err != nil {
+		return newTaskError(CodeTypeMismatch, err.Error(), err)
 	}
 
 	return nil
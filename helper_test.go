@@ -1,6 +1,7 @@
 package asynctask
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -90,3 +91,52 @@ func TestResultObjUnmarshalError(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestResultGenericDirectMatch(t *testing.T) {
+	actual, err := Result[int](42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, actual)
+}
+
+func TestResultGenericNilReturnsErrNilResult(t *testing.T) {
+	actual, err := Result[string](nil)
+
+	assert.ErrorIs(t, err, ErrNilResult)
+	assert.Equal(t, "", actual)
+}
+
+func TestResultGenericStructFromMap(t *testing.T) {
+	type T struct {
+		Key string `json:"key"`
+		Val string `json:"val"`
+	}
+
+	source := map[string]interface{}{
+		"key": "keyTest",
+		"val": "valTest",
+	}
+
+	actual, err := Result[T](source)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "keyTest", actual.Key)
+	assert.Equal(t, "valTest", actual.Val)
+}
+
+func TestResultGenericSliceFromJSONBytes(t *testing.T) {
+	actual, err := Result[[]int]([]byte("[1,2,3]"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, actual)
+}
+
+func TestResultGenericTypeMismatch(t *testing.T) {
+	actual, err := Result[int](make(chan int))
+
+	assert.Equal(t, 0, actual)
+
+	var typeMismatch *ErrTypeMismatch
+	assert.ErrorAs(t, err, &typeMismatch)
+	assert.Equal(t, reflect.TypeOf(0), typeMismatch.Expected)
+}
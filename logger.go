@@ -5,14 +5,45 @@ import (
 	"os"
 )
 
-var (
-	infoLogger  *log.Logger
-	warnLogger  *log.Logger
-	errorLogger *log.Logger
-)
+// Logger is the interface asynctask uses to report internal diagnostics, such as
+// recovered panics. Set a custom implementation with AsyncTask.SetLogger to plug
+// asynctask into your own logging stack
+type Logger interface {
+	Info(msg string)
+	Warn(msg string)
+	Error(msg string)
+}
+
+type stdLogger struct {
+	info  *log.Logger
+	warn  *log.Logger
+	error *log.Logger
+}
+
+func newStdLogger() *stdLogger {
+	return &stdLogger{
+		info:  log.New(os.Stdout, "asynctask: INFO: ", log.Ldate|log.Ltime),
+		warn:  log.New(os.Stdout, "asynctask: WARNING: ", log.Ldate|log.Ltime),
+		error: log.New(os.Stderr, "asynctask: ERROR: ", log.Ldate|log.Ltime),
+	}
+}
+
+func (l *stdLogger) Info(msg string) {
+	l.info.Println(msg)
+}
+
+func (l *stdLogger) Warn(msg string) {
+	l.warn.Println(msg)
+}
+
+func (l *stdLogger) Error(msg string) {
+	l.error.Println(msg)
+}
+
+var defaultLogger Logger = newStdLogger()
 
-func init() {
-	infoLogger = log.New(os.Stdout, "asynctask: INFO: ", log.Ldate|log.Ltime)
-	warnLogger = log.New(os.Stdout, "asynctask: WARNING: ", log.Ldate|log.Ltime)
-	errorLogger = log.New(os.Stderr, "asynctask: ERROR: ", log.Ldate|log.Ltime)
+// SetLogger replaces the default Logger used to report internal diagnostics
+func (b *AsyncTask) SetLogger(l Logger) *AsyncTask {
+	b.logger = l
+	return b
 }
@@ -0,0 +1,60 @@
+package asynctask
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// RunnerStats carries the runtime characteristics of a single runner execution,
+// reported to an Observer's OnFinish once the runner completes
+type RunnerStats struct {
+	// Duration is the wall-clock time spent actually running the function, retries included
+	Duration time.Duration
+	// RetryCount is how many retries (not counting the first attempt) were performed
+	RetryCount int
+	// TimedOut is true if the last attempt hit its SetTimeout limit
+	TimedOut bool
+	// GoroutineID is the id of the goroutine the function ran on, for correlating with
+	// stack dumps. It is a best-effort value parsed from the runtime stack trace
+	GoroutineID uint64
+	// PoolWait is how long the runner was blocked waiting for a free slot in the
+	// pool set by AsyncTask.SetRunnerPoolSize before it started running
+	PoolWait time.Duration
+}
+
+// Observer receives lifecycle notifications for every runner an AsyncTask executes.
+// Set one with AsyncTask.SetObserver to plug asynctask into a metrics/tracing stack
+type Observer interface {
+	OnStart(id string)
+	OnFinish(id string, stats RunnerStats)
+	OnPanic(id string, r interface{}, stack []byte)
+}
+
+type noopObserver struct{}
+
+func (noopObserver) OnStart(string)                      {}
+func (noopObserver) OnFinish(string, RunnerStats)        {}
+func (noopObserver) OnPanic(string, interface{}, []byte) {}
+
+// SetObserver replaces the default no-op Observer used to report per-runner metrics
+func (b *AsyncTask) SetObserver(o Observer) *AsyncTask {
+	b.observer = o
+	return b
+}
+
+// currentGoroutineID parses the calling goroutine's id out of its own stack trace.
+// It is best-effort and meant for observability only, never for control flow
+func currentGoroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	fields := bytes.Fields(buf)
+	if len(fields) < 2 {
+		return 0
+	}
+
+	id, _ := strconv.ParseUint(string(fields[1]), 10, 64)
+	return id
+}
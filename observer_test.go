@@ -0,0 +1,120 @@
+package asynctask
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingObserver struct {
+	mutex   sync.Mutex
+	started []string
+	stats   map[string]RunnerStats
+}
+
+func (o *recordingObserver) OnStart(id string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.started = append(o.started, id)
+}
+
+func (o *recordingObserver) OnFinish(id string, stats RunnerStats) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.stats == nil {
+		o.stats = make(map[string]RunnerStats)
+	}
+	o.stats[id] = stats
+}
+
+func (o *recordingObserver) OnPanic(string, interface{}, []byte) {}
+
+func TestAsyncTaskObserverReceivesStats(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	observer := &recordingObserver{}
+	asyncTask.SetObserver(observer)
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		time.Sleep(10 * time.Millisecond)
+		return "ok", nil
+	}).Register("id1")
+
+	err := asyncTask.StartAndWait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id1"}, observer.started)
+	assert.GreaterOrEqual(t, observer.stats["id1"].Duration, 10*time.Millisecond)
+	assert.False(t, observer.stats["id1"].TimedOut)
+}
+
+func TestAsyncTaskObserverRetryCount(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	observer := &recordingObserver{}
+	asyncTask.SetObserver(observer)
+
+	attempts := 0
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, assert.AnError
+		}
+		return "ok", nil
+	}).SetRetry(5, ConstantBackoff{Delay: time.Millisecond}).Register("id1")
+
+	err := asyncTask.StartAndWait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, observer.stats["id1"].RetryCount)
+}
+
+func TestAsyncTaskObserverPoolWait(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	asyncTask.SetRunnerPoolSize(1)
+	observer := &recordingObserver{}
+	asyncTask.SetObserver(observer)
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		time.Sleep(50 * time.Millisecond)
+		return "first", nil
+	}).Register("id1")
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		return "second", nil
+	}).Register("id2")
+
+	err := asyncTask.StartAndWait()
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, observer.stats["id2"].PoolWait, 40*time.Millisecond)
+}
+
+type recordingLogger struct {
+	mutex  sync.Mutex
+	errors []string
+}
+
+func (l *recordingLogger) Info(string) {}
+func (l *recordingLogger) Warn(string) {}
+func (l *recordingLogger) Error(msg string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.errors = append(l.errors, msg)
+}
+
+func TestAsyncTaskCustomLoggerReceivesPanic(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+	logger := &recordingLogger{}
+	asyncTask.SetLogger(logger)
+
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		panic("boom")
+	}).Register("id1")
+
+	err := asyncTask.StartAndWait()
+
+	assert.Error(t, err)
+	assert.Len(t, logger.errors, 1)
+	assert.Contains(t, logger.errors[0], "boom")
+}
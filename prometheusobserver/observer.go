@@ -0,0 +1,63 @@
+// Package prometheusobserver implements an asynctask.Observer that exports runner
+// metrics as Prometheus counters and histograms. It lives in its own subpackage so
+// importing asynctask does not pull in the Prometheus client unless this adapter is used.
+package prometheusobserver
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/ramabmtr/asynctask"
+)
+
+// Observer implements asynctask.Observer, recording how long each runner ID takes,
+// how many times it ran, and how many times it panicked
+type Observer struct {
+	duration *prometheus.HistogramVec
+	total    *prometheus.CounterVec
+	panics   *prometheus.CounterVec
+}
+
+// New creates an Observer and registers its metrics on reg
+func New(reg prometheus.Registerer) *Observer {
+	o := &Observer{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "asynctask",
+			Name:      "runner_duration_seconds",
+			Help:      "Duration of asynctask runner executions.",
+		}, []string{"id", "status"}),
+		total: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "asynctask",
+			Name:      "runner_total",
+			Help:      "Total number of asynctask runner executions.",
+		}, []string{"id", "status"}),
+		panics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "asynctask",
+			Name:      "runner_panics_total",
+			Help:      "Total number of asynctask runner panics.",
+		}, []string{"id"}),
+	}
+
+	reg.MustRegister(o.duration, o.total, o.panics)
+
+	return o
+}
+
+// OnStart implements asynctask.Observer
+func (o *Observer) OnStart(string) {}
+
+// OnFinish implements asynctask.Observer
+func (o *Observer) OnFinish(id string, stats asynctask.RunnerStats) {
+	status := "ok"
+	if stats.TimedOut {
+		status = "timeout"
+	}
+
+	o.duration.WithLabelValues(id, status).Observe(stats.Duration.Seconds())
+	o.total.WithLabelValues(id, status).Inc()
+}
+
+// OnPanic implements asynctask.Observer
+func (o *Observer) OnPanic(id string, _ interface{}, _ []byte) {
+	o.panics.WithLabelValues(id).Inc()
+}
+
+var _ asynctask.Observer = (*Observer)(nil)
@@ -0,0 +1,131 @@
+package asynctask
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// BackoffPolicy decides how long to wait before the next retry attempt, where
+// attempt is the 1-indexed number of the attempt that just failed
+type BackoffPolicy interface {
+	Backoff(attempt int) time.Duration
+}
+
+// ConstantBackoff waits the same delay before every retry attempt
+type ConstantBackoff struct {
+	Delay time.Duration
+}
+
+// Backoff implements BackoffPolicy
+func (c ConstantBackoff) Backoff(int) time.Duration {
+	return c.Delay
+}
+
+type exponentialBackoff struct {
+	base   time.Duration
+	max    time.Duration
+	jitter float64
+}
+
+// ExponentialBackoff returns a BackoffPolicy that doubles the delay after every
+// attempt starting from base, capped at max, with +/-jitter percent of randomness
+// added to avoid retry storms (e.g. jitter of 0.1 adds up to 10% random variance)
+func ExponentialBackoff(base, max time.Duration, jitter float64) BackoffPolicy {
+	return exponentialBackoff{base: base, max: max, jitter: jitter}
+}
+
+// Backoff implements BackoffPolicy
+func (e exponentialBackoff) Backoff(attempt int) time.Duration {
+	delay := e.base << (attempt - 1)
+	if delay <= 0 || delay > e.max {
+		delay = e.max
+	}
+
+	if e.jitter > 0 {
+		variance := float64(delay) * e.jitter
+		delay = time.Duration(float64(delay) - variance + rand.Float64()*2*variance)
+	}
+
+	return delay
+}
+
+// SetRetry sets the number of attempts (including the first one) a runner's function
+// is given before its error is surfaced, and the BackoffPolicy to wait between attempts
+func (r *Runner) SetRetry(attempts int, backoff BackoffPolicy) *Runner {
+	r.retryAttempts = attempts
+	r.retryBackoff = backoff
+	return r
+}
+
+// SetRetryIf sets a predicate used to tell retriable errors (e.g. network hiccups)
+// from terminal ones (e.g. validation errors). When unset, every error is retried
+func (r *Runner) SetRetryIf(f func(error) bool) *Runner {
+	r.retryIf = f
+	return r
+}
+
+// SetOnRetry sets a callback invoked with the attempt number and error right before
+// a retry is scheduled, useful for logging
+func (r *Runner) SetOnRetry(f func(attempt int, err error)) *Runner {
+	r.onRetry = f
+	return r
+}
+
+func (r *Runner) shouldRetry(err error) bool {
+	if r.retryIf == nil {
+		return true
+	}
+	return r.retryIf(err)
+}
+
+// invokeWithRetry runs attempt() up to r.retryAttempts times, waiting according to
+// r.retryBackoff between attempts, and wraps the last error with the attempt count
+func (r *Runner) invokeWithRetry() (interface{}, error) {
+	attempts := r.retryAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	made := 0
+	for i := 1; i <= attempts; i++ {
+		resp, err := r.attempt()
+		made = i
+		if err == nil {
+			return resp, nil
+		}
+
+		lastErr = err
+
+		if i == attempts || !r.shouldRetry(err) {
+			break
+		}
+
+		r.retryCount = i
+
+		if r.onRetry != nil {
+			r.onRetry(i, err)
+		}
+		r.b.events.emit(TaskEvent{ID: r.id, Err: err, Kind: EventRetrying})
+
+		if r.retryBackoff != nil {
+			select {
+			case <-time.After(r.retryBackoff.Backoff(i)):
+			case <-r.b.ctx.Done():
+				return nil, lastErr
+			}
+		}
+	}
+
+	if made > 1 {
+		code := CodeHandlerFailed
+		var taskErr *TaskError
+		if errors.As(lastErr, &taskErr) {
+			code = taskErr.code
+		}
+		return nil, newTaskError(code, fmt.Sprintf("runner with ID %s failed after %d attempt(s)", r.id, made), lastErr)
+	}
+	return nil, lastErr
+}
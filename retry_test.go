@@ -0,0 +1,75 @@
+package asynctask
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncTaskRetrySucceedAfterFailures(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+
+	attempts := 0
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("transient")
+		}
+		return "ok", nil
+	}).SetRetry(5, ConstantBackoff{Delay: time.Millisecond}).Register("id1")
+
+	err := asyncTask.StartAndWait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, "ok", asyncTask.GetResult("id1"))
+}
+
+func TestAsyncTaskRetryExhaustedError(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+
+	attempts := 0
+	testErr := errors.New("permanent")
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		attempts++
+		return nil, testErr
+	}).SetRetry(3, ConstantBackoff{Delay: time.Millisecond}).Register("id1")
+
+	err := asyncTask.StartAndWait()
+
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Contains(t, err.Error(), "failed after 3 attempt(s)")
+	assert.True(t, errors.Is(err, testErr))
+}
+
+func TestAsyncTaskRetryIfNotRetriable(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+
+	attempts := 0
+	testErr := errors.New("validation error")
+	asyncTask.NewRunner().SetFunc(func(param interface{}) (interface{}, error) {
+		attempts++
+		return nil, testErr
+	}).SetRetry(5, ConstantBackoff{Delay: time.Millisecond}).SetRetryIf(func(err error) bool {
+		return false
+	}).Register("id1")
+
+	err := asyncTask.StartAndWait()
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+	assert.NotContains(t, err.Error(), "failed after 5 attempt(s)")
+}
+
+func TestExponentialBackoffCapsAtMax(t *testing.T) {
+	backoff := ExponentialBackoff(10*time.Millisecond, 40*time.Millisecond, 0)
+
+	assert.Equal(t, 10*time.Millisecond, backoff.Backoff(1))
+	assert.Equal(t, 20*time.Millisecond, backoff.Backoff(2))
+	assert.Equal(t, 40*time.Millisecond, backoff.Backoff(3))
+	assert.Equal(t, 40*time.Millisecond, backoff.Backoff(4))
+}
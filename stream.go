@@ -0,0 +1,95 @@
+package asynctask
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// StreamResult wraps a possibly large or streamed task output, capped at a caller
+// chosen limit so consuming it cannot exhaust memory on a runaway task
+type StreamResult struct {
+	Data      []byte
+	Truncated bool
+	MaxBytes  int
+}
+
+// String renders Data, appending a truncation marker when Data was cut off so it
+// reads nicely in CLIs and logs
+func (s StreamResult) String() string {
+	out := string(s.Data)
+	if s.Truncated {
+		out += "\n[truncated...]\n"
+	}
+	return out
+}
+
+// ResultStream reads at most maxBytes out of v, which may be a string, []byte,
+// io.Reader or fmt.Stringer, and reports whether more data was available
+func ResultStream(v interface{}, maxBytes int) (StreamResult, error) {
+	r, err := streamSource(v)
+	if err != nil {
+		return StreamResult{}, err
+	}
+
+	buf := make([]byte, maxBytes+1)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return StreamResult{}, err
+	}
+
+	truncated := n > maxBytes
+	if truncated {
+		n = maxBytes
+	}
+
+	return StreamResult{Data: buf[:n], Truncated: truncated, MaxBytes: maxBytes}, nil
+}
+
+// ResultLines is like ResultStream but caps the output at maxLines lines instead of
+// a byte count, for line-oriented outputs such as logs or query rows
+func ResultLines(v interface{}, maxLines int) (StreamResult, error) {
+	r, err := streamSource(v)
+	if err != nil {
+		return StreamResult{}, err
+	}
+
+	scanner := bufio.NewScanner(r)
+	lines := make([]string, 0, maxLines)
+	truncated := false
+	for scanner.Scan() {
+		if len(lines) >= maxLines {
+			truncated = true
+			break
+		}
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return StreamResult{}, err
+	}
+
+	return StreamResult{Data: []byte(strings.Join(lines, "\n")), Truncated: truncated}, nil
+}
+
+// streamSource turns a runner result into an io.Reader, failing fast for sources
+// that have no meaningful way to be read as a stream
+func streamSource(v interface{}) (io.Reader, error) {
+	switch src := v.(type) {
+	case nil:
+		return nil, newTaskError(CodeNilResult, "result is nil", ErrNilResult)
+	case string:
+		return strings.NewReader(src), nil
+	case []byte:
+		return bytes.NewReader(src), nil
+	case io.Reader:
+		return src, nil
+	case fmt.Stringer:
+		return strings.NewReader(src.String()), nil
+	default:
+		mismatch := &ErrTypeMismatch{Expected: reflect.TypeOf(""), Actual: reflect.TypeOf(v)}
+		return nil, newTaskError(CodeTypeMismatch, mismatch.Error(), mismatch)
+	}
+}
@@ -0,0 +1,55 @@
+package asynctask
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultStreamNotTruncated(t *testing.T) {
+	res, err := ResultStream("hello", 10)
+
+	assert.NoError(t, err)
+	assert.False(t, res.Truncated)
+	assert.Equal(t, "hello", res.String())
+}
+
+func TestResultStreamTruncated(t *testing.T) {
+	res, err := ResultStream("hello world", 5)
+
+	assert.NoError(t, err)
+	assert.True(t, res.Truncated)
+	assert.Equal(t, "hello", string(res.Data))
+	assert.Contains(t, res.String(), "[truncated...]")
+}
+
+func TestResultStreamFromReader(t *testing.T) {
+	res, err := ResultStream(strings.NewReader("stream data"), 100)
+
+	assert.NoError(t, err)
+	assert.False(t, res.Truncated)
+	assert.Equal(t, "stream data", string(res.Data))
+}
+
+func TestResultStreamUnsupportedType(t *testing.T) {
+	_, err := ResultStream(42, 10)
+
+	assert.Equal(t, CodeTypeMismatch, Code(err))
+}
+
+func TestResultLinesTruncated(t *testing.T) {
+	res, err := ResultLines("line1\nline2\nline3", 2)
+
+	assert.NoError(t, err)
+	assert.True(t, res.Truncated)
+	assert.Equal(t, "line1\nline2", string(res.Data))
+}
+
+func TestResultLinesNotTruncated(t *testing.T) {
+	res, err := ResultLines("line1\nline2", 5)
+
+	assert.NoError(t, err)
+	assert.False(t, res.Truncated)
+	assert.Equal(t, "line1\nline2", string(res.Data))
+}
@@ -0,0 +1,62 @@
+package asynctask
+
+import (
+	"context"
+	"time"
+)
+
+// TypedRunner is a generics-based wrapper around Runner that removes the need for
+// interface{} params/results and the Result*/ResultObj casting helpers
+type TypedRunner[P, R any] struct {
+	runner *Runner
+	param  P
+}
+
+// NewTypedRunner creates a TypedRunner registered against t, running f with the
+// param set via SetParam once the runner is scheduled
+func NewTypedRunner[P, R any](t *AsyncTask, f func(ctx context.Context, param P) (R, error)) *TypedRunner[P, R] {
+	tr := &TypedRunner[P, R]{}
+	tr.runner = t.NewRunner().SetFuncWithInputs(func(ctx context.Context, _ map[string]interface{}) (interface{}, error) {
+		return f(ctx, tr.param)
+	})
+	return tr
+}
+
+// SetParam is to set the typed param that will be thrown to the executed function
+func (tr *TypedRunner[P, R]) SetParam(param P) *TypedRunner[P, R] {
+	tr.param = param
+	return tr
+}
+
+// SetTimeout is to set the typed runner wait time for f to return a result, see Runner.SetTimeout
+func (tr *TypedRunner[P, R]) SetTimeout(x time.Duration) *TypedRunner[P, R] {
+	tr.runner.SetTimeout(x)
+	return tr
+}
+
+// SetRetry sets the retry policy for the typed runner, see Runner.SetRetry
+func (tr *TypedRunner[P, R]) SetRetry(attempts int, backoff BackoffPolicy) *TypedRunner[P, R] {
+	tr.runner.SetRetry(attempts, backoff)
+	return tr
+}
+
+// After declares that this runner must only start once every runner registered
+// with the given IDs has finished successfully, see Runner.After
+func (tr *TypedRunner[P, R]) After(ids ...string) *TypedRunner[P, R] {
+	tr.runner.After(ids...)
+	return tr
+}
+
+// Register is to register the typed runner to asynctask
+func (tr *TypedRunner[P, R]) Register(id string) {
+	tr.runner.Register(id)
+}
+
+// GetTypedResult is to get a result from asynctask by ID with a compile-time type,
+// the second return value is false if no result was stored for id or its type does
+// not match R
+func GetTypedResult[R any](t *AsyncTask, id string) (R, bool) {
+	v := t.GetResult(id)
+	r, ok := v.(R)
+	return r, ok
+}
@@ -0,0 +1,52 @@
+package asynctask
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedRunnerSucceed(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+
+	NewTypedRunner(asyncTask, func(ctx context.Context, param int) (string, error) {
+		return "result", nil
+	}).SetParam(42).Register("id1")
+
+	err := asyncTask.StartAndWait()
+
+	assert.NoError(t, err)
+
+	actual, ok := GetTypedResult[string](asyncTask, "id1")
+	assert.True(t, ok)
+	assert.Equal(t, "result", actual)
+}
+
+func TestTypedRunnerError(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+
+	testErr := errors.New("failed")
+	NewTypedRunner(asyncTask, func(ctx context.Context, param int) (string, error) {
+		return "", testErr
+	}).SetParam(1).Register("id1")
+
+	err := asyncTask.StartAndWait()
+
+	assert.Error(t, err)
+}
+
+func TestGetTypedResultWrongType(t *testing.T) {
+	asyncTask := NewAsyncTask(context.Background())
+
+	NewTypedRunner(asyncTask, func(ctx context.Context, param int) (int, error) {
+		return param * 2, nil
+	}).SetParam(2).Register("id1")
+
+	err := asyncTask.StartAndWait()
+	assert.NoError(t, err)
+
+	_, ok := GetTypedResult[string](asyncTask, "id1")
+	assert.False(t, ok)
+}